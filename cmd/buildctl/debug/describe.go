@@ -0,0 +1,145 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/moby/buildkit/client"
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	"github.com/pkg/errors"
+	"github.com/tonistiigi/units"
+	"github.com/urfave/cli"
+)
+
+var describeWorkerCommand = cli.Command{
+	Name:      "describe",
+	Usage:     "show details of a single worker",
+	ArgsUsage: "<id>",
+	Action:    describeWorker,
+}
+
+// describeWorker prints a kubectl-describe-style report for a single worker,
+// resolved by exact ID or unique ID prefix, combining the fields already
+// collected by printWorkersVerbose with live-derived cache disk usage per GC
+// policy rule. In-flight builds are not attributed here: the client package
+// has no RPC that maps a running build to the worker executing it yet.
+func describeWorker(clicontext *cli.Context) error {
+	id := clicontext.Args().First()
+	if id == "" {
+		return errors.New("worker id required")
+	}
+
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+
+	ctx := commandContext(clicontext)
+
+	workers, err := c.ListWorkers(ctx)
+	if err != nil {
+		return err
+	}
+
+	wi, err := findWorker(workers, id)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(clicontext.App.Writer, 1, 8, 1, '\t', 0)
+	fmt.Fprintf(tw, "ID:\t%s\n", wi.ID)
+	fmt.Fprintf(tw, "Platforms:\t%s\n", joinPlatforms(wi.Platforms))
+	fmt.Fprintf(tw, "BuildKit:\t%s %s %s\n", wi.BuildkitVersion.Package, wi.BuildkitVersion.Version, wi.BuildkitVersion.Revision)
+
+	fmt.Fprintf(tw, "Labels:\n")
+	for _, k := range sortedKeys(wi.Labels) {
+		fmt.Fprintf(tw, "\t%s:\t%s\n", k, wi.Labels[k])
+	}
+
+	if len(wi.CDIDevices) > 0 {
+		fmt.Fprint(tw, "Devices:\n")
+		for _, d := range wi.CDIDevices {
+			fmt.Fprintf(tw, "\tName:\t%s\n", d.Name)
+			if d.OnDemand {
+				fmt.Fprintf(tw, "\tOnDemand:\t%v\n", d.OnDemand)
+			} else {
+				fmt.Fprintf(tw, "\tAutoAllow:\t%v\n", d.AutoAllow)
+			}
+			for _, k := range sortedKeys(d.Annotations) {
+				fmt.Fprintf(tw, "\tAnnotations:\t%s:\t%s\n", k, d.Annotations[k])
+			}
+		}
+	}
+
+	for i, rule := range wi.GCPolicy {
+		fmt.Fprintf(tw, "GC Policy rule#%d:\n", i)
+		fmt.Fprintf(tw, "\tAll:\t%v\n", rule.All)
+		if len(rule.Filter) > 0 {
+			fmt.Fprintf(tw, "\tFilters:\t%s\n", strings.Join(rule.Filter, " "))
+		}
+		if rule.KeepDuration > 0 {
+			fmt.Fprintf(tw, "\tKeep duration:\t%v\n", rule.KeepDuration.String())
+		}
+		if rule.ReservedSpace > 0 {
+			fmt.Fprintf(tw, "\tReserved space:\t%g\n", units.Bytes(rule.ReservedSpace))
+		}
+		if rule.MinFreeSpace > 0 {
+			fmt.Fprintf(tw, "\tMinimum free space:\t%g\n", units.Bytes(rule.MinFreeSpace))
+		}
+		if rule.MaxUsedSpace > 0 {
+			fmt.Fprintf(tw, "\tMaximum used space:\t%g\n", units.Bytes(rule.MaxUsedSpace))
+		}
+
+		used, err := diskUsageForFilter(ctx, c, rule.Filter)
+		if err != nil {
+			fmt.Fprintf(tw, "\tDisk usage:\t<error: %s>\n", err)
+			continue
+		}
+		fmt.Fprintf(tw, "\tDisk usage:\t%g\n", units.Bytes(used))
+	}
+
+	return tw.Flush()
+}
+
+// findWorker resolves id against the worker list by exact match first, then
+// by unique ID prefix.
+func findWorker(workers []*client.WorkerInfo, id string) (*client.WorkerInfo, error) {
+	for _, wi := range workers {
+		if wi.ID == id {
+			return wi, nil
+		}
+	}
+
+	var match *client.WorkerInfo
+	for _, wi := range workers {
+		if !strings.HasPrefix(wi.ID, id) {
+			continue
+		}
+		if match != nil {
+			return nil, errors.Errorf("ambiguous worker id prefix %q", id)
+		}
+		match = wi
+	}
+	if match == nil {
+		return nil, errors.Errorf("worker %q not found", id)
+	}
+	return match, nil
+}
+
+// diskUsageForFilter sums the cache record sizes reported by client.DiskUsage
+// for the given GC policy filter, so a rule's "Reserved space"/"Maximum used
+// space" can be compared against how much cache its filter currently
+// matches. Every matching record is counted, including records in use.
+func diskUsageForFilter(ctx context.Context, c *client.Client, filter []string) (int64, error) {
+	du, err := c.DiskUsage(ctx, client.WithFilter(filter))
+	if err != nil {
+		return 0, err
+	}
+	var total int64
+	for _, r := range du {
+		total += r.Size
+	}
+	return total, nil
+}
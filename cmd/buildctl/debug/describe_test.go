@@ -0,0 +1,59 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/client"
+)
+
+func TestFindWorker(t *testing.T) {
+	workers := []*client.WorkerInfo{
+		{ID: "abc123"},
+		{ID: "abc456"},
+		{ID: "def789"},
+	}
+
+	t.Run("exact match", func(t *testing.T) {
+		wi, err := findWorker(workers, "def789")
+		if err != nil {
+			t.Fatalf("findWorker returned error: %v", err)
+		}
+		if wi.ID != "def789" {
+			t.Fatalf("findWorker() = %+v, want ID def789", wi)
+		}
+	})
+
+	t.Run("unique prefix", func(t *testing.T) {
+		wi, err := findWorker(workers, "def")
+		if err != nil {
+			t.Fatalf("findWorker returned error: %v", err)
+		}
+		if wi.ID != "def789" {
+			t.Fatalf("findWorker() = %+v, want ID def789", wi)
+		}
+	})
+
+	t.Run("exact match preferred over a prefix collision", func(t *testing.T) {
+		wi, err := findWorker(workers, "abc123")
+		if err != nil {
+			t.Fatalf("findWorker returned error: %v", err)
+		}
+		if wi.ID != "abc123" {
+			t.Fatalf("findWorker() = %+v, want ID abc123", wi)
+		}
+	})
+
+	t.Run("ambiguous prefix", func(t *testing.T) {
+		_, err := findWorker(workers, "abc")
+		if err == nil {
+			t.Fatal("findWorker() = nil error, want ambiguous prefix error")
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := findWorker(workers, "zzz")
+		if err == nil {
+			t.Fatal("findWorker() = nil error, want not found error")
+		}
+	})
+}
@@ -0,0 +1,228 @@
+package debug
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	bccommon "github.com/moby/buildkit/cmd/buildctl/common"
+	digest "github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var exportWorkersCommand = cli.Command{
+	Name:      "export",
+	Usage:     "export worker inventory as a pushable OCI image layout, optionally wrapped as an in-toto attestation",
+	ArgsUsage: "<output-dir>",
+	Flags: []cli.Flag{
+		cli.StringSliceFlag{
+			Name:  "filter, f",
+			Usage: "containerd-style filter string slice, same grammar as `workers --filter`",
+		},
+		cli.BoolFlag{
+			Name:  "attestation",
+			Usage: "wrap the inventory as an in-toto attestation predicate instead of a plain inventory manifest",
+		},
+		cli.StringFlag{
+			Name:  "subject",
+			Usage: "image reference as name@sha256:<digest> that this attestation's Subject describes; required with --attestation",
+		},
+	},
+	Action: exportWorkers,
+}
+
+// workerInventoryMediaType identifies the plain (non-attestation) inventory
+// layer blob: the worker list itself, using the same stable schema as
+// `--format json`.
+const workerInventoryMediaType = "application/vnd.buildkit.worker-inventory.v1+json"
+
+// inTotoMediaType is the media type docker/buildx attestations use for the
+// in-toto statement layer of an attestation manifest.
+const inTotoMediaType = "application/vnd.in-toto+json"
+
+// inTotoPredicateTypeAnnotation is the manifest annotation convention
+// attestation consumers use to read the predicate type without parsing the
+// layer blob.
+const inTotoPredicateTypeAnnotation = "in-toto.io/predicate-type"
+
+// workerInventoryPredicateType is the in-toto predicate type used when
+// --attestation wraps the inventory in an in-toto Statement.
+const workerInventoryPredicateType = "https://buildkit.moby/attestations/worker-inventory/v1"
+
+// workerInventory is the JSON payload describing the worker fleet: a
+// versioned list of WorkerInfo records.
+type workerInventory struct {
+	MediaType string           `json:"mediaType"`
+	Workers   []workerInfoJSON `json:"workers"`
+}
+
+// inTotoSubject mirrors in-toto's Subject shape (name + digest set), which
+// is narrower than ocispecs.Descriptor and is what verifiers expect to match
+// against the artifact the attestation describes.
+type inTotoSubject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// inTotoStatement is a minimal in-toto v1 Statement used to wrap the worker
+// inventory as a provenance attestation. It isn't backed by the full
+// in-toto-golang dependency since only this one predicate type is needed.
+type inTotoStatement struct {
+	Type          string          `json:"_type"`
+	Subject       []inTotoSubject `json:"subject"`
+	PredicateType string          `json:"predicateType"`
+	Predicate     workerInventory `json:"predicate"`
+}
+
+func exportWorkers(clicontext *cli.Context) error {
+	outputDir := clicontext.Args().First()
+	if outputDir == "" {
+		return errors.New("output directory required: buildctl debug workers export <output-dir>")
+	}
+
+	attestation := clicontext.Bool("attestation")
+	subjectRef := clicontext.String("subject")
+	if attestation && subjectRef == "" {
+		return errors.New("--subject is required with --attestation")
+	}
+
+	c, err := bccommon.ResolveClient(clicontext)
+	if err != nil {
+		return err
+	}
+
+	workers, err := listWorkersFiltered(commandContext(clicontext), c, clicontext.StringSlice("filter"))
+	if err != nil {
+		return err
+	}
+
+	infos := make([]workerInfoJSON, 0, len(workers))
+	for _, wi := range workers {
+		infos = append(infos, toWorkerInfoJSON(wi))
+	}
+	inventory := workerInventory{MediaType: workerInventoryMediaType, Workers: infos}
+
+	layout, err := newImageLayout(outputDir)
+	if err != nil {
+		return err
+	}
+
+	var layer ocispecs.Descriptor
+	if attestation {
+		name, dgst, err := parseSubjectRef(subjectRef)
+		if err != nil {
+			return err
+		}
+		statement := inTotoStatement{
+			Type:          "https://in-toto.io/Statement/v1",
+			Subject:       []inTotoSubject{{Name: name, Digest: map[string]string{dgst.Algorithm().String(): dgst.Encoded()}}},
+			PredicateType: workerInventoryPredicateType,
+			Predicate:     inventory,
+		}
+		layer, err = layout.writeJSONBlob(inTotoMediaType, statement)
+		if err != nil {
+			return err
+		}
+		layer.Annotations = map[string]string{inTotoPredicateTypeAnnotation: workerInventoryPredicateType}
+	} else {
+		layer, err = layout.writeJSONBlob(workerInventoryMediaType, inventory)
+		if err != nil {
+			return err
+		}
+	}
+
+	config, err := layout.writeBlob(ocispecs.DescriptorEmptyJSON.MediaType, ocispecs.DescriptorEmptyJSON.Data)
+	if err != nil {
+		return err
+	}
+
+	manifest := ocispecs.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispecs.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []ocispecs.Descriptor{layer},
+	}
+	manifestDesc, err := layout.writeJSONBlob(manifest.MediaType, manifest)
+	if err != nil {
+		return err
+	}
+
+	return layout.writeIndex(manifestDesc)
+}
+
+// imageLayout writes an OCI image layout directory (oci-layout, index.json,
+// blobs/sha256/<digest>) so the exported inventory is a real artifact that
+// tools like `oras`/`skopeo` can push to a registry, rather than a
+// manifest that merely references blobs existing nowhere.
+type imageLayout struct {
+	dir string
+}
+
+func newImageLayout(dir string) (*imageLayout, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "blobs", "sha256"), 0o755); err != nil {
+		return nil, err
+	}
+	layoutFile := struct {
+		ImageLayoutVersion string `json:"imageLayoutVersion"`
+	}{ImageLayoutVersion: "1.0.0"}
+	b, err := json.Marshal(layoutFile)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "oci-layout"), b, 0o644); err != nil {
+		return nil, err
+	}
+	return &imageLayout{dir: dir}, nil
+}
+
+func (l *imageLayout) writeBlob(mediaType string, data []byte) (ocispecs.Descriptor, error) {
+	dgst := digest.FromBytes(data)
+	path := filepath.Join(l.dir, "blobs", dgst.Algorithm().String(), dgst.Encoded())
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return ocispecs.Descriptor{}, err
+	}
+	return ocispecs.Descriptor{
+		MediaType: mediaType,
+		Digest:    dgst,
+		Size:      int64(len(data)),
+	}, nil
+}
+
+func (l *imageLayout) writeJSONBlob(mediaType string, v any) (ocispecs.Descriptor, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ocispecs.Descriptor{}, err
+	}
+	return l.writeBlob(mediaType, data)
+}
+
+func (l *imageLayout) writeIndex(manifest ocispecs.Descriptor) error {
+	index := ocispecs.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: ocispecs.MediaTypeImageIndex,
+		Manifests: []ocispecs.Descriptor{manifest},
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(l.dir, "index.json"), data, 0o644)
+}
+
+// parseSubjectRef splits a "name@sha256:<digest>" reference into its name
+// and digest, as used for OCI manifest/attestation subjects.
+func parseSubjectRef(ref string) (string, digest.Digest, error) {
+	name, dgstStr, ok := strings.Cut(ref, "@")
+	if !ok {
+		return "", "", errors.Errorf("invalid --subject %q: expected name@sha256:<digest>", ref)
+	}
+	dgst, err := digest.Parse(dgstStr)
+	if err != nil {
+		return "", "", errors.Wrapf(err, "invalid --subject digest %q", ref)
+	}
+	return name, dgst, nil
+}
@@ -0,0 +1,102 @@
+package debug
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	digest "github.com/opencontainers/go-digest"
+)
+
+func TestImageLayoutWriteBlob(t *testing.T) {
+	dir := t.TempDir()
+	layout, err := newImageLayout(dir)
+	if err != nil {
+		t.Fatalf("newImageLayout returned error: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "oci-layout")); err != nil {
+		t.Fatalf("oci-layout not written: %v", err)
+	}
+
+	data := []byte(`{"hello":"world"}`)
+	desc, err := layout.writeBlob("application/json", data)
+	if err != nil {
+		t.Fatalf("writeBlob returned error: %v", err)
+	}
+
+	wantDigest := digest.FromBytes(data)
+	if desc.Digest != wantDigest {
+		t.Fatalf("writeBlob() digest = %s, want %s", desc.Digest, wantDigest)
+	}
+	if desc.Size != int64(len(data)) {
+		t.Fatalf("writeBlob() size = %d, want %d", desc.Size, len(data))
+	}
+
+	blobPath := filepath.Join(dir, "blobs", wantDigest.Algorithm().String(), wantDigest.Encoded())
+	got, err := os.ReadFile(blobPath)
+	if err != nil {
+		t.Fatalf("blob not written at %s: %v", blobPath, err)
+	}
+	if string(got) != string(data) {
+		t.Fatalf("blob content = %q, want %q", got, data)
+	}
+}
+
+func TestImageLayoutWriteIndexReferencesWrittenManifest(t *testing.T) {
+	dir := t.TempDir()
+	layout, err := newImageLayout(dir)
+	if err != nil {
+		t.Fatalf("newImageLayout returned error: %v", err)
+	}
+
+	manifestDesc, err := layout.writeJSONBlob("application/vnd.oci.image.manifest.v1+json", map[string]string{"k": "v"})
+	if err != nil {
+		t.Fatalf("writeJSONBlob returned error: %v", err)
+	}
+	if err := layout.writeIndex(manifestDesc); err != nil {
+		t.Fatalf("writeIndex returned error: %v", err)
+	}
+
+	indexData, err := os.ReadFile(filepath.Join(dir, "index.json"))
+	if err != nil {
+		t.Fatalf("index.json not written: %v", err)
+	}
+	var index struct {
+		Manifests []struct {
+			Digest string `json:"digest"`
+		} `json:"manifests"`
+	}
+	if err := json.Unmarshal(indexData, &index); err != nil {
+		t.Fatalf("index.json is not valid JSON: %v", err)
+	}
+	if len(index.Manifests) != 1 || index.Manifests[0].Digest != manifestDesc.Digest.String() {
+		t.Fatalf("index.json manifests = %+v, want a single entry for %s", index.Manifests, manifestDesc.Digest)
+	}
+
+	blobPath := filepath.Join(dir, "blobs", manifestDesc.Digest.Algorithm().String(), manifestDesc.Digest.Encoded())
+	if _, err := os.Stat(blobPath); err != nil {
+		t.Fatalf("manifest blob referenced by index.json is missing on disk: %v", err)
+	}
+}
+
+func TestParseSubjectRef(t *testing.T) {
+	name, dgst, err := parseSubjectRef("example.com/app@sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855")
+	if err != nil {
+		t.Fatalf("parseSubjectRef returned error: %v", err)
+	}
+	if name != "example.com/app" {
+		t.Fatalf("parseSubjectRef() name = %q, want example.com/app", name)
+	}
+	if dgst.Algorithm().String() != "sha256" {
+		t.Fatalf("parseSubjectRef() digest algorithm = %q, want sha256", dgst.Algorithm())
+	}
+
+	if _, _, err := parseSubjectRef("no-at-sign"); err == nil {
+		t.Fatal("parseSubjectRef(\"no-at-sign\") = nil error, want error")
+	}
+	if _, _, err := parseSubjectRef("example.com/app@not-a-digest"); err == nil {
+		t.Fatal("parseSubjectRef with an invalid digest = nil error, want error")
+	}
+}
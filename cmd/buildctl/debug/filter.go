@@ -0,0 +1,293 @@
+package debug
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/containerd/platforms"
+	"github.com/moby/buildkit/client"
+	"github.com/pkg/errors"
+)
+
+// listWorkersFiltered parses raw into the richer containerd-style grammar.
+// When every group can be expressed in the daemon's native filter syntax
+// (equality/inequality only), it is forwarded unchanged so existing
+// deployments see no behavior change. Otherwise the unfiltered worker list
+// is fetched once and the full grammar, including glob and semver
+// comparisons, is evaluated client-side.
+func listWorkersFiltered(ctx context.Context, c *client.Client, raw []string) ([]*client.WorkerInfo, error) {
+	groups, err := parseFilterGroups(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	allServerCompatible := true
+	for _, g := range groups {
+		if !g.serverCompatible() {
+			allServerCompatible = false
+			break
+		}
+	}
+	if allServerCompatible {
+		return c.ListWorkers(ctx, client.WithFilter(raw))
+	}
+
+	workers, err := c.ListWorkers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]*client.WorkerInfo, 0, len(workers))
+	for _, wi := range workers {
+		ok, err := matchesAnyFilterGroup(wi, groups)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, wi)
+		}
+	}
+	return filtered, nil
+}
+
+// filterExpr is a single containerd-style predicate, e.g. "labels.foo==bar"
+// or "buildkit.version>=0.13".
+type filterExpr struct {
+	Key   string
+	Op    string
+	Value string
+}
+
+// filterGroup is a comma-separated set of expressions that must all match
+// (AND). Separate --filter flags are OR'd together, matching containerd's
+// filter CLI convention.
+type filterGroup []filterExpr
+
+// filterOps lists the supported operators. Their order does not affect
+// parsing: parseFilterExpr picks the left-most operator in the expression.
+var filterOps = []string{"~=", ">=", "<=", "==", "!="}
+
+// parseFilterGroups parses the raw --filter values into OR'd AND-groups.
+func parseFilterGroups(raw []string) ([]filterGroup, error) {
+	groups := make([]filterGroup, 0, len(raw))
+	for _, s := range raw {
+		var group filterGroup
+		for _, part := range strings.Split(s, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			expr, err := parseFilterExpr(part)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, expr)
+		}
+		if len(group) > 0 {
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// parseFilterExpr finds the left-most operator in s (so "labels.foo!=a==b"
+// splits on "!=", not the "==" inside the value), breaking ties toward the
+// longer operator when two candidates start at the same index.
+func parseFilterExpr(s string) (filterExpr, error) {
+	bestIdx := -1
+	var bestOp string
+	for _, op := range filterOps {
+		idx := strings.Index(s, op)
+		if idx < 0 {
+			continue
+		}
+		if bestIdx == -1 || idx < bestIdx || (idx == bestIdx && len(op) > len(bestOp)) {
+			bestIdx, bestOp = idx, op
+		}
+	}
+	if bestIdx == -1 {
+		return filterExpr{}, errors.Errorf("invalid filter expression %q", s)
+	}
+	return filterExpr{Key: s[:bestIdx], Op: bestOp, Value: s[bestIdx+len(bestOp):]}, nil
+}
+
+// isServerKnownKey reports whether key is one of the predicate keys the
+// daemon's existing containerd-style filter support already understood before the richer
+// client-side grammar (platform, cdi.device, buildkit.version, globs,
+// semver comparisons) was added here.
+func isServerKnownKey(key string) bool {
+	return key == "id" || strings.HasPrefix(key, "labels.")
+}
+
+// serverCompatible reports whether expr can be evaluated by the daemon's
+// existing containerd-style filter support, which only understands equality
+// and inequality on a known set of keys. Glob, semver, and the CDI/platform/
+// version keys introduced here are always evaluated client-side, regardless
+// of operator.
+func (e filterExpr) serverCompatible() bool {
+	return isServerKnownKey(e.Key) && (e.Op == "==" || e.Op == "!=")
+}
+
+// serverCompatible reports whether every expression in the group can be
+// forwarded to the daemon as-is.
+func (g filterGroup) serverCompatible() bool {
+	for _, e := range g {
+		if !e.serverCompatible() {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesAnyFilterGroup evaluates the OR of AND-groups against wi. An empty
+// group list matches everything.
+func matchesAnyFilterGroup(wi *client.WorkerInfo, groups []filterGroup) (bool, error) {
+	if len(groups) == 0 {
+		return true, nil
+	}
+	for _, g := range groups {
+		ok, err := g.match(wi)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (g filterGroup) match(wi *client.WorkerInfo) (bool, error) {
+	for _, e := range g {
+		ok, err := e.match(wi)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (e filterExpr) match(wi *client.WorkerInfo) (bool, error) {
+	switch {
+	case e.Key == "id":
+		return e.compareString(wi.ID)
+	case strings.HasPrefix(e.Key, "labels."):
+		return e.compareString(wi.Labels[strings.TrimPrefix(e.Key, "labels.")])
+	case e.Key == "platform":
+		for _, p := range wi.Platforms {
+			ok, err := e.compareString(platforms.Format(platforms.Normalize(p)))
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	case e.Key == "cdi.device":
+		for _, d := range wi.CDIDevices {
+			ok, err := e.compareString(d.Name)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+		return false, nil
+	case e.Key == "buildkit.version":
+		return e.compareVersion(wi.BuildkitVersion.Version)
+	default:
+		return false, errors.Errorf("unsupported filter key %q", e.Key)
+	}
+}
+
+func (e filterExpr) compareString(v string) (bool, error) {
+	switch e.Op {
+	case "==":
+		return v == e.Value, nil
+	case "!=":
+		return v != e.Value, nil
+	case "~=":
+		return globMatch(e.Value, v)
+	default:
+		return false, errors.Errorf("operator %q is not valid for key %q", e.Op, e.Key)
+	}
+}
+
+// globMatch matches pattern against v using containerd-style glob semantics,
+// where "*" matches any run of characters, including path separators (unlike
+// path/filepath.Match, which stops a "*" at "/" and would otherwise silently
+// fail to match multi-segment platform strings like "linux/arm/v7" against
+// "linux/*").
+func globMatch(pattern, v string) (bool, error) {
+	var b strings.Builder
+	b.WriteByte('^')
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteByte('$')
+
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return false, errors.Wrapf(err, "invalid glob pattern %q", pattern)
+	}
+	return re.MatchString(v), nil
+}
+
+// compareVersion supports >= and <= on dotted numeric versions (the common
+// case for BuildKit release versions). A missing trailing segment counts as
+// 0, and within a segment a non-numeric or pre-release suffix is compared
+// lexically only when the leading digits are equal.
+func (e filterExpr) compareVersion(v string) (bool, error) {
+	cmp := compareVersions(v, e.Value)
+	switch e.Op {
+	case ">=":
+		return cmp >= 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	default:
+		return false, errors.Errorf("operator %q is not valid for key %q", e.Op, e.Key)
+	}
+}
+
+func compareVersions(a, b string) int {
+	as := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	bs := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		av, bv := "0", "0"
+		if i < len(as) {
+			av = as[i]
+		}
+		if i < len(bs) {
+			bv = bs[i]
+		}
+		asuf := strings.TrimLeft(av, "0123456789")
+		bsuf := strings.TrimLeft(bv, "0123456789")
+		an, _ := strconv.Atoi(strings.TrimSuffix(av, asuf))
+		bn, _ := strconv.Atoi(strings.TrimSuffix(bv, bsuf))
+		if an != bn {
+			return an - bn
+		}
+		if asuf != bsuf {
+			return strings.Compare(asuf, bsuf)
+		}
+	}
+	return 0
+}
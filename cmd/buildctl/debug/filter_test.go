@@ -0,0 +1,157 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/moby/buildkit/client"
+	ocispecs "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+func TestParseFilterExpr(t *testing.T) {
+	cases := []struct {
+		name    string
+		in      string
+		want    filterExpr
+		wantErr bool
+	}{
+		{
+			name: "equality",
+			in:   "labels.foo==bar",
+			want: filterExpr{Key: "labels.foo", Op: "==", Value: "bar"},
+		},
+		{
+			name: "inequality",
+			in:   "labels.foo!=bar",
+			want: filterExpr{Key: "labels.foo", Op: "!=", Value: "bar"},
+		},
+		{
+			name: "glob",
+			in:   "platform~=linux/*",
+			want: filterExpr{Key: "platform", Op: "~=", Value: "linux/*"},
+		},
+		{
+			name: "semver gte",
+			in:   "buildkit.version>=0.13",
+			want: filterExpr{Key: "buildkit.version", Op: ">=", Value: "0.13"},
+		},
+		{
+			name: "value containing another operator splits on the left-most op",
+			in:   "labels.foo!=a==b",
+			want: filterExpr{Key: "labels.foo", Op: "!=", Value: "a==b"},
+		},
+		{
+			name:    "no operator",
+			in:      "labels.foo",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := parseFilterExpr(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("parseFilterExpr(%q) = %+v, want error", tc.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFilterExpr(%q) returned error: %v", tc.in, err)
+			}
+			if got != tc.want {
+				t.Fatalf("parseFilterExpr(%q) = %+v, want %+v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFilterExprServerCompatible(t *testing.T) {
+	cases := []struct {
+		name string
+		expr filterExpr
+		want bool
+	}{
+		{name: "labels equality", expr: filterExpr{Key: "labels.foo", Op: "=="}, want: true},
+		{name: "labels inequality", expr: filterExpr{Key: "labels.foo", Op: "!="}, want: true},
+		{name: "id equality", expr: filterExpr{Key: "id", Op: "=="}, want: true},
+		{name: "buildkit version equality is still client-only", expr: filterExpr{Key: "buildkit.version", Op: "=="}, want: false},
+		{name: "cdi device equality is still client-only", expr: filterExpr{Key: "cdi.device", Op: "=="}, want: false},
+		{name: "platform glob", expr: filterExpr{Key: "platform", Op: "~="}, want: false},
+		{name: "labels glob is not server-supported", expr: filterExpr{Key: "labels.foo", Op: "~="}, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.expr.serverCompatible(); got != tc.want {
+				t.Fatalf("filterExpr{%q,%q}.serverCompatible() = %v, want %v", tc.expr.Key, tc.expr.Op, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"0.13", "0.13", 0},
+		{"0.13.1", "0.13", 1},
+		{"0.12", "0.13", -1},
+		{"v0.13.0", "0.13", 0},
+	}
+
+	for _, tc := range cases {
+		got := compareVersions(tc.a, tc.b)
+		if (got < 0) != (tc.want < 0) || (got > 0) != (tc.want > 0) || (got == 0) != (tc.want == 0) {
+			t.Fatalf("compareVersions(%q, %q) = %d, want sign %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+// TestMatchesAnyFilterGroupMixedServerAndClientKeys covers the fallback path
+// taken by listWorkersFiltered when one --filter group is server-compatible
+// in isolation (id==) but another forces client-side evaluation
+// (platform~=): the id group must still be evaluatable client-side instead
+// of erroring with "unsupported filter key".
+func TestMatchesAnyFilterGroupMixedServerAndClientKeys(t *testing.T) {
+	wi := &client.WorkerInfo{
+		ID:        "abc",
+		Platforms: []ocispecs.Platform{{OS: "linux", Architecture: "arm", Variant: "v7"}},
+	}
+
+	groups, err := parseFilterGroups([]string{"id==abc", "platform~=linux/*"})
+	if err != nil {
+		t.Fatalf("parseFilterGroups returned error: %v", err)
+	}
+
+	ok, err := matchesAnyFilterGroup(wi, groups)
+	if err != nil {
+		t.Fatalf("matchesAnyFilterGroup returned error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("matchesAnyFilterGroup() = false, want true via the id==abc group")
+	}
+}
+
+func TestGlobMatch(t *testing.T) {
+	cases := []struct {
+		pattern, value string
+		want           bool
+	}{
+		{"linux/*", "linux/amd64", true},
+		{"linux/*", "linux/arm/v7", true}, // "*" must cross "/", unlike path/filepath.Match
+		{"linux/*", "windows/amd64", false},
+		{"linux/arm/*", "linux/arm/v7", true},
+		{"linux/arm/*", "linux/arm64", false},
+	}
+
+	for _, tc := range cases {
+		got, err := globMatch(tc.pattern, tc.value)
+		if err != nil {
+			t.Fatalf("globMatch(%q, %q) returned error: %v", tc.pattern, tc.value, err)
+		}
+		if got != tc.want {
+			t.Fatalf("globMatch(%q, %q) = %v, want %v", tc.pattern, tc.value, got, tc.want)
+		}
+	}
+}
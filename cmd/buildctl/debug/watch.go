@@ -0,0 +1,109 @@
+package debug
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"text/tabwriter"
+	"time"
+
+	"github.com/moby/buildkit/client"
+	"github.com/urfave/cli"
+)
+
+// ansiClearScreen homes the cursor and clears the terminal, the same
+// sequence `kubectl get -w` relies on to re-render a table in place.
+const ansiClearScreen = "\033[H\033[2J"
+
+// watchWorkers polls ListWorkers on an interval, diffing successive
+// snapshots by worker ID and re-rendering the table in place. It runs until
+// the command context is canceled (e.g. Ctrl-C).
+func watchWorkers(clicontext *cli.Context, c *client.Client) error {
+	ctx := commandContext(clicontext)
+	interval := clicontext.Duration("interval")
+	filters := clicontext.StringSlice("filter")
+
+	var prev []*client.WorkerInfo
+	for {
+		workers, err := listWorkersFiltered(ctx, c, filters)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprint(clicontext.App.Writer, ansiClearScreen)
+
+		tw := tabwriter.NewWriter(os.Stdout, 1, 8, 1, '\t', 0)
+		printWorkersTable(tw, workers)
+
+		for _, msg := range diffWorkers(prev, workers) {
+			fmt.Fprintln(clicontext.App.Writer, msg)
+		}
+
+		prev = workers
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// diffWorkers compares two successive worker snapshots by ID and describes
+// workers that were added, removed, or had their labels or GC policy
+// change.
+func diffWorkers(prev, cur []*client.WorkerInfo) []string {
+	prevByID := make(map[string]*client.WorkerInfo, len(prev))
+	for _, wi := range prev {
+		prevByID[wi.ID] = wi
+	}
+	curByID := make(map[string]*client.WorkerInfo, len(cur))
+	for _, wi := range cur {
+		curByID[wi.ID] = wi
+	}
+
+	var msgs []string
+	for _, id := range sortedKeys(curByID) {
+		wi := curByID[id]
+		old, ok := prevByID[id]
+		if !ok {
+			msgs = append(msgs, fmt.Sprintf("+ worker %s added", id))
+			continue
+		}
+		if changed := diffWorkerLabelsAndGCPolicy(old, wi); changed != "" {
+			msgs = append(msgs, fmt.Sprintf("~ worker %s changed: %s", id, changed))
+		}
+	}
+	for _, id := range sortedKeys(prevByID) {
+		if _, ok := curByID[id]; !ok {
+			msgs = append(msgs, fmt.Sprintf("- worker %s removed", id))
+		}
+	}
+	return msgs
+}
+
+func diffWorkerLabelsAndGCPolicy(old, cur *client.WorkerInfo) string {
+	var changes []string
+	if !mapsEqual(old.Labels, cur.Labels) {
+		changes = append(changes, "labels")
+	}
+	if !reflect.DeepEqual(old.GCPolicy, cur.GCPolicy) {
+		changes = append(changes, "GC policy")
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%v", changes)
+}
+
+func mapsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+	return true
+}
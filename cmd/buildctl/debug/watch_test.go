@@ -0,0 +1,107 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+)
+
+func TestMapsEqual(t *testing.T) {
+	cases := []struct {
+		name string
+		a, b map[string]string
+		want bool
+	}{
+		{name: "both nil", a: nil, b: nil, want: true},
+		{name: "equal", a: map[string]string{"a": "1"}, b: map[string]string{"a": "1"}, want: true},
+		{name: "different value", a: map[string]string{"a": "1"}, b: map[string]string{"a": "2"}, want: false},
+		{name: "different length", a: map[string]string{"a": "1"}, b: map[string]string{"a": "1", "b": "2"}, want: false},
+		{name: "different key", a: map[string]string{"a": "1"}, b: map[string]string{"b": "1"}, want: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := mapsEqual(tc.a, tc.b); got != tc.want {
+				t.Fatalf("mapsEqual(%v, %v) = %v, want %v", tc.a, tc.b, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDiffWorkers(t *testing.T) {
+	base := &client.WorkerInfo{
+		ID:     "w1",
+		Labels: map[string]string{"org": "buildkit"},
+		GCPolicy: []client.PruneInfo{
+			{All: true, KeepDuration: time.Minute},
+		},
+	}
+
+	t.Run("added", func(t *testing.T) {
+		msgs := diffWorkers(nil, []*client.WorkerInfo{base})
+		if len(msgs) != 1 || msgs[0] != "+ worker w1 added" {
+			t.Fatalf("diffWorkers() = %v, want a single add message", msgs)
+		}
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		msgs := diffWorkers([]*client.WorkerInfo{base}, nil)
+		if len(msgs) != 1 || msgs[0] != "- worker w1 removed" {
+			t.Fatalf("diffWorkers() = %v, want a single remove message", msgs)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		same := *base
+		msgs := diffWorkers([]*client.WorkerInfo{base}, []*client.WorkerInfo{&same})
+		if len(msgs) != 0 {
+			t.Fatalf("diffWorkers() = %v, want no messages for an unchanged worker", msgs)
+		}
+	})
+
+	t.Run("GC policy rule count unchanged but content changed is still reported", func(t *testing.T) {
+		changed := *base
+		changed.GCPolicy = []client.PruneInfo{
+			{All: true, KeepDuration: 2 * time.Minute},
+		}
+		msgs := diffWorkers([]*client.WorkerInfo{base}, []*client.WorkerInfo{&changed})
+		if len(msgs) != 1 {
+			t.Fatalf("diffWorkers() = %v, want a single change message", msgs)
+		}
+	})
+
+	t.Run("labels changed", func(t *testing.T) {
+		changed := *base
+		changed.Labels = map[string]string{"org": "moby"}
+		msgs := diffWorkers([]*client.WorkerInfo{base}, []*client.WorkerInfo{&changed})
+		if len(msgs) != 1 {
+			t.Fatalf("diffWorkers() = %v, want a single change message", msgs)
+		}
+	})
+
+	t.Run("multiple changes are reported in deterministic ID order", func(t *testing.T) {
+		w1 := &client.WorkerInfo{ID: "w1"}
+		w2 := &client.WorkerInfo{ID: "w2"}
+		w3 := &client.WorkerInfo{ID: "w3"}
+
+		prev := []*client.WorkerInfo{w2, w3}
+		cur := []*client.WorkerInfo{w1, w2}
+
+		want := []string{
+			"+ worker w1 added",
+			"- worker w3 removed",
+		}
+		for i := 0; i < 5; i++ {
+			msgs := diffWorkers(prev, cur)
+			if len(msgs) != len(want) {
+				t.Fatalf("diffWorkers() = %v, want %v", msgs, want)
+			}
+			for i, m := range msgs {
+				if m != want[i] {
+					t.Fatalf("diffWorkers() = %v, want %v", msgs, want)
+				}
+			}
+		}
+	})
+}
@@ -2,12 +2,14 @@ package debug
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"maps"
 	"os"
 	"slices"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/containerd/platforms"
 	"github.com/moby/buildkit/client"
@@ -25,7 +27,7 @@ var WorkersCommand = cli.Command{
 	Flags: []cli.Flag{
 		cli.StringSliceFlag{
 			Name:  "filter, f",
-			Usage: "containerd-style filter string slice",
+			Usage: "containerd-style filter string slice, e.g. 'labels.foo==bar', 'platform~=linux/*', 'cdi.device==nvidia.com/gpu=all', 'buildkit.version>=0.13'",
 		},
 		cli.BoolFlag{
 			Name:  "verbose, v",
@@ -33,9 +35,125 @@ var WorkersCommand = cli.Command{
 		},
 		cli.StringFlag{
 			Name:  "format",
-			Usage: "Format the output using the given Go template, e.g, '{{json .}}'",
+			Usage: "Format the output using the given Go template, e.g, '{{json .}}', or 'json'/'json-pretty' for the stable JSON schema",
+		},
+		cli.BoolFlag{
+			Name:  "watch, w",
+			Usage: "Watch for worker changes, re-rendering the table in place",
+		},
+		cli.DurationFlag{
+			Name:  "interval",
+			Usage: "Polling interval to use with --watch",
+			Value: 2 * time.Second,
 		},
 	},
+	Subcommands: []cli.Command{
+		describeWorkerCommand,
+		exportWorkersCommand,
+	},
+}
+
+// workerInfoJSON is the stable schema emitted by `--format json` and
+// `--format json-pretty`. Byte-valued GC policy fields are rendered both as
+// raw integers (for machine consumption) and as a human-readable sibling
+// string (matching the units used by the verbose tabwriter output), so
+// tooling can depend on the numeric fields without losing the formatted
+// view.
+type workerInfoJSON struct {
+	ID              string                 `json:"ID"`
+	Platforms       []string               `json:"Platforms"`
+	Labels          map[string]string      `json:"Labels"`
+	CDIDevices      []cdiDeviceJSON        `json:"CDIDevices,omitempty"`
+	BuildkitVersion client.BuildkitVersion `json:"BuildkitVersion"`
+	GCPolicy        []gcPolicyJSON         `json:"GCPolicy,omitempty"`
+}
+
+type cdiDeviceJSON struct {
+	Name        string            `json:"Name"`
+	AutoAllow   bool              `json:"AutoAllow"`
+	OnDemand    bool              `json:"OnDemand"`
+	Annotations map[string]string `json:"Annotations,omitempty"`
+}
+
+type gcPolicyJSON struct {
+	All                bool     `json:"All"`
+	Filter             []string `json:"Filter,omitempty"`
+	KeepDuration       int64    `json:"KeepDuration,omitempty"`
+	KeepDurationHuman  string   `json:"KeepDurationHuman,omitempty"`
+	ReservedSpace      int64    `json:"ReservedSpace,omitempty"`
+	ReservedSpaceHuman string   `json:"ReservedSpaceHuman,omitempty"`
+	MinFreeSpace       int64    `json:"MinFreeSpace,omitempty"`
+	MinFreeSpaceHuman  string   `json:"MinFreeSpaceHuman,omitempty"`
+	MaxUsedSpace       int64    `json:"MaxUsedSpace,omitempty"`
+	MaxUsedSpaceHuman  string   `json:"MaxUsedSpaceHuman,omitempty"`
+}
+
+func toWorkerInfoJSON(wi *client.WorkerInfo) workerInfoJSON {
+	platformStrs := make([]string, 0, len(wi.Platforms))
+	for _, p := range wi.Platforms {
+		platformStrs = append(platformStrs, platforms.Format(platforms.Normalize(p)))
+	}
+
+	devices := make([]cdiDeviceJSON, 0, len(wi.CDIDevices))
+	for _, d := range wi.CDIDevices {
+		devices = append(devices, cdiDeviceJSON{
+			Name:        d.Name,
+			AutoAllow:   d.AutoAllow,
+			OnDemand:    d.OnDemand,
+			Annotations: d.Annotations,
+		})
+	}
+
+	rules := make([]gcPolicyJSON, 0, len(wi.GCPolicy))
+	for _, rule := range wi.GCPolicy {
+		gc := gcPolicyJSON{
+			All:    rule.All,
+			Filter: rule.Filter,
+		}
+		// Each *Human sibling is only populated when its raw field is
+		// non-zero, so the two stay in sync under json:",omitempty" instead
+		// of the human string (e.g. "0s") surviving while the raw int is
+		// omitted.
+		if rule.KeepDuration > 0 {
+			gc.KeepDuration = int64(rule.KeepDuration)
+			gc.KeepDurationHuman = rule.KeepDuration.String()
+		}
+		if rule.ReservedSpace > 0 {
+			gc.ReservedSpace = rule.ReservedSpace
+			gc.ReservedSpaceHuman = fmt.Sprintf("%g", units.Bytes(rule.ReservedSpace))
+		}
+		if rule.MinFreeSpace > 0 {
+			gc.MinFreeSpace = rule.MinFreeSpace
+			gc.MinFreeSpaceHuman = fmt.Sprintf("%g", units.Bytes(rule.MinFreeSpace))
+		}
+		if rule.MaxUsedSpace > 0 {
+			gc.MaxUsedSpace = rule.MaxUsedSpace
+			gc.MaxUsedSpaceHuman = fmt.Sprintf("%g", units.Bytes(rule.MaxUsedSpace))
+		}
+		rules = append(rules, gc)
+	}
+
+	return workerInfoJSON{
+		ID:              wi.ID,
+		Platforms:       platformStrs,
+		Labels:          wi.Labels,
+		CDIDevices:      devices,
+		BuildkitVersion: wi.BuildkitVersion,
+		GCPolicy:        rules,
+	}
+}
+
+func printWorkersJSON(w *cli.App, winfo []*client.WorkerInfo, pretty bool) error {
+	out := make([]workerInfoJSON, 0, len(winfo))
+	for _, wi := range winfo {
+		out = append(out, toWorkerInfoJSON(wi))
+	}
+
+	enc := json.NewEncoder(w.Writer)
+	if pretty {
+		enc.SetIndent("", "  ")
+	}
+	return enc.Encode(out)
 }
 
 func listWorkers(clicontext *cli.Context) error {
@@ -44,7 +162,11 @@ func listWorkers(clicontext *cli.Context) error {
 		return err
 	}
 
-	workers, err := c.ListWorkers(commandContext(clicontext), client.WithFilter(clicontext.StringSlice("filter")))
+	if clicontext.Bool("watch") {
+		return watchWorkers(clicontext, c)
+	}
+
+	workers, err := listWorkersFiltered(commandContext(clicontext), c, clicontext.StringSlice("filter"))
 	if err != nil {
 		return err
 	}
@@ -52,6 +174,12 @@ func listWorkers(clicontext *cli.Context) error {
 		if clicontext.Bool("verbose") {
 			bklog.L.Debug("Ignoring --verbose")
 		}
+		switch format {
+		case "json":
+			return printWorkersJSON(clicontext.App, workers, false)
+		case "json-pretty":
+			return printWorkersJSON(clicontext.App, workers, true)
+		}
 		tmpl, err := bccommon.ParseTemplate(format)
 		if err != nil {
 			return err
@@ -0,0 +1,45 @@
+package debug
+
+import (
+	"testing"
+	"time"
+
+	"github.com/moby/buildkit/client"
+)
+
+func TestToWorkerInfoJSONGCPolicyZeroGating(t *testing.T) {
+	wi := &client.WorkerInfo{
+		ID: "w1",
+		GCPolicy: []client.PruneInfo{
+			{All: true},
+			{All: true, KeepDuration: time.Minute, ReservedSpace: 1024},
+		},
+	}
+
+	rules := toWorkerInfoJSON(wi).GCPolicy
+	if len(rules) != 2 {
+		t.Fatalf("toWorkerInfoJSON().GCPolicy has %d rules, want 2", len(rules))
+	}
+
+	zero := rules[0]
+	if zero.KeepDuration != 0 || zero.KeepDurationHuman != "" {
+		t.Fatalf("zero-valued rule: KeepDuration=%d KeepDurationHuman=%q, want both unset", zero.KeepDuration, zero.KeepDurationHuman)
+	}
+	if zero.ReservedSpace != 0 || zero.ReservedSpaceHuman != "" {
+		t.Fatalf("zero-valued rule: ReservedSpace=%d ReservedSpaceHuman=%q, want both unset", zero.ReservedSpace, zero.ReservedSpaceHuman)
+	}
+	if zero.MinFreeSpace != 0 || zero.MinFreeSpaceHuman != "" {
+		t.Fatalf("zero-valued rule: MinFreeSpace=%d MinFreeSpaceHuman=%q, want both unset", zero.MinFreeSpace, zero.MinFreeSpaceHuman)
+	}
+	if zero.MaxUsedSpace != 0 || zero.MaxUsedSpaceHuman != "" {
+		t.Fatalf("zero-valued rule: MaxUsedSpace=%d MaxUsedSpaceHuman=%q, want both unset", zero.MaxUsedSpace, zero.MaxUsedSpaceHuman)
+	}
+
+	set := rules[1]
+	if set.KeepDuration == 0 || set.KeepDurationHuman == "" {
+		t.Fatalf("populated rule: KeepDuration=%d KeepDurationHuman=%q, want both set", set.KeepDuration, set.KeepDurationHuman)
+	}
+	if set.ReservedSpace == 0 || set.ReservedSpaceHuman == "" {
+		t.Fatalf("populated rule: ReservedSpace=%d ReservedSpaceHuman=%q, want both set", set.ReservedSpace, set.ReservedSpaceHuman)
+	}
+}